@@ -1,21 +1,44 @@
 package main
 
 import (
+	_ "embed"
+	"flag"
 	"fmt"
+	"math"
+	"os"
+	"strings"
 	"unicode"
 )
 
 // main is the entry point that calls the solution functions.
+//
+// Passing --password turns this file into a usable pre-commit style gate:
+//
+//	go run 03_challenge_solutions.go --password 'Tr0ub4dor&3' --min-score 3
+//
+// exits 0 when the password's Score meets --min-score, and 1 otherwise. With
+// no --password, it just runs the original three challenge demos.
 func main() {
+	password := flag.String("password", "", "password to analyze; if set, skips the demo output and acts as a gate")
+	minScore := flag.Int("min-score", 3, "minimum acceptable PasswordStrength.Score (0-4) when --password is set")
+	flag.Parse()
+
+	if *password != "" {
+		strength := AnalyzePassword(*password)
+		printStrength(*password, strength)
+		if strength.Score < *minScore {
+			os.Exit(1)
+		}
+		return
+	}
+
 	fmt.Println("--- Challenge 1: FizzBuzz ---")
 	fizzBuzz()
 
 	fmt.Println("\n--- Challenge 2: Password Checker ---")
-	passwordChecker("Password123") // A valid password
-	passwordChecker("short")      // Invalid: too short
-	passwordChecker("longpassword") // Invalid: no uppercase or number
-	passwordChecker("LongPassword") // Invalid: no number
-	passwordChecker("password123")  // Invalid: no uppercase
+	for _, p := range []string{"Password123", "short", "longpassword", "LongPassword", "password123", "correct horse battery staple"} {
+		printStrength(p, AnalyzePassword(p))
+	}
 
 	fmt.Println("\n--- Challenge 3: Sum of Numbers ---")
 	sumOfNumbers(100)
@@ -52,51 +75,281 @@ func fizzBuzz() {
 }
 
 // --- Challenge 2: Password Checker ---
-// Goal: Check if a password meets the following criteria:
-// - At least 8 characters long.
-// - Contains at least one uppercase letter.
-// - Contains at least one number.
+// Goal: Go beyond "does it have an uppercase letter and a digit" and
+// actually estimate how hard a password would be to crack.
+//
+// NOTE ON PROJECT LAYOUT: this file is a standalone `package main` like the
+// rest of go/examples/, run directly with `go run`. The types and functions
+// below (PasswordStrength, AnalyzePassword, ...) are named and exported as
+// a future `passwords` package would name them so other examples can reuse
+// the analyzer once this repo grows a go.mod to import across files.
 
-func passwordChecker(password string) {
-	fmt.Printf("Checking password: '%s'\n", password)
-	// We'll use boolean flags to keep track of whether each condition is met.
-	var hasMinLength bool
-	var hasUppercase bool
-	var hasNumber bool
-
-	// 1. Check for minimum length.
-	if len(password) >= 8 {
-		hasMinLength = true
-	}
-
-	// 2. Iterate over the string to check for uppercase letters and numbers.
-	// A 'for...range' loop on a string iterates over its runes (characters).
-	for _, char := range password {
-		// The 'unicode' package has helpful functions for character classification.
-		if unicode.IsUpper(char) {
-			hasUppercase = true
+//go:embed data/common_passwords.txt
+var commonPasswordsRaw string
+
+// commonPasswords is commonPasswordsRaw split into a set for O(1) lookups.
+// NOTE: this is a synthetically generated 10,000-entry sample, not a real
+// breached-password dump - there's no network access available to fetch one
+// here. It's built from ~300 well-known weak base words (password, qwerty,
+// dragon, ...) crossed with the numeric suffixes real users commonly tack
+// on ("password1", "dragon12", ...), which approximates the
+// shape of a real top-10k list, but isn't one: it won't catch breached
+// passwords that aren't a base word from this list plus a suffix.
+var commonPasswords = buildCommonPasswordSet(commonPasswordsRaw)
+
+func buildCommonPasswordSet(raw string) map[string]bool {
+	set := make(map[string]bool)
+	for _, line := range strings.Split(raw, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			set[line] = true
+		}
+	}
+	return set
+}
+
+// dictionaryWords are the plain-alphabetic base words behind the embedded
+// password list (e.g. "password" rather than "password1" or "dragon2023"),
+// reused to catch leetspeak variants of common words - "p4ssw0rd99" isn't
+// itself in the breached-password set, but it's built from "password".
+var dictionaryWords = buildDictionaryWords(commonPasswordsRaw)
+
+func buildDictionaryWords(raw string) []string {
+	seen := make(map[string]bool)
+	var words []string
+	for _, line := range strings.Split(raw, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || len(line) < 4 || !isAlpha(line) || seen[line] {
+			continue
+		}
+		seen[line] = true
+		words = append(words, line)
+	}
+	return words
+}
+
+func isAlpha(s string) bool {
+	for _, r := range s {
+		if !unicode.IsLetter(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// leetDictionaryMatch reports the first dictionary word found inside a
+// de-leeted password, e.g. "p4ssw0rd99" -> "password".
+func leetDictionaryMatch(deleeted string) (string, bool) {
+	for _, word := range dictionaryWords {
+		if strings.Contains(deleeted, word) {
+			return word, true
+		}
+	}
+	return "", false
+}
+
+// PasswordStrength is the result of analyzing one password.
+type PasswordStrength struct {
+	Score            int     // 0 (terrible) to 4 (excellent)
+	EntropyBits      float64 // log2(character pool size) * length, minus pattern penalties, floored at 0
+	EstimatedGuesses float64 // 2^EntropyBits, i.e. the expected brute-force search space
+	Warnings         []string
+	Suggestions      []string
+}
+
+// keyboardWalks are short substrings typed by sliding along a keyboard row.
+var keyboardWalks = []string{"qwerty", "qwertz", "azerty", "asdf", "zxcv", "1qaz", "qazwsx"}
+
+// AnalyzePassword scores a password's strength and explains why.
+func AnalyzePassword(password string) PasswordStrength {
+	var s PasswordStrength
+	lower := strings.ToLower(password)
+	deleeted := deleetify(lower)
+
+	isCommon := commonPasswords[lower] || commonPasswords[deleeted]
+	if isCommon {
+		s.Warnings = append(s.Warnings, "this is one of the most common breached passwords")
+		s.Suggestions = append(s.Suggestions, "pick something nobody else would ever type")
+	}
+
+	poolSize := 0
+	var hasLower, hasUpper, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		default:
+			hasSymbol = true
+		}
+	}
+	if hasLower {
+		poolSize += 26
+	}
+	if hasUpper {
+		poolSize += 26
+	}
+	if hasDigit {
+		poolSize += 10
+	}
+	if hasSymbol {
+		poolSize += 32
+	}
+	if poolSize == 0 {
+		poolSize = 1 // guard against log2(0) for an empty password
+	}
+
+	s.EntropyBits = math.Log2(float64(poolSize)) * float64(len(password))
+
+	if run := longestSequentialRun(lower); run >= 4 {
+		s.EntropyBits -= float64(run) * 2
+		s.Warnings = append(s.Warnings, "contains a sequential run like \"abcd\" or \"1234\"")
+		s.Suggestions = append(s.Suggestions, "break up runs of consecutive letters or digits")
+	}
+	if run := longestRepeatedRun(password); run >= 4 {
+		s.EntropyBits -= float64(run) * 2
+		s.Warnings = append(s.Warnings, "contains a repeated character like \"aaaa\"")
+		s.Suggestions = append(s.Suggestions, "avoid repeating the same character many times")
+	}
+	for _, walk := range keyboardWalks {
+		if strings.Contains(lower, walk) {
+			s.EntropyBits -= float64(len(walk)) * 2
+			s.Warnings = append(s.Warnings, "contains a keyboard walk like \"qwerty\" or \"asdf\"")
+			s.Suggestions = append(s.Suggestions, "don't slide your fingers along the keyboard")
+			break
 		}
-		if unicode.IsDigit(char) {
-			hasNumber = true
+	}
+	if looksLikeDate(password) {
+		s.EntropyBits -= 10
+		s.Warnings = append(s.Warnings, "contains what looks like a year or date")
+		s.Suggestions = append(s.Suggestions, "avoid birthdays and other memorable dates")
+	}
+	if word, ok := leetDictionaryMatch(deleeted); ok && !isCommon {
+		s.EntropyBits -= float64(len(word)) * 2
+		s.Warnings = append(s.Warnings, fmt.Sprintf("contains a leetspeak variant of the common word %q", word))
+		s.Suggestions = append(s.Suggestions, "avoid dictionary words, even with letter/number substitutions")
+	}
+	if s.EntropyBits < 0 {
+		s.EntropyBits = 0
+	}
+
+	s.EstimatedGuesses = math.Pow(2, s.EntropyBits)
+
+	if len(password) < 8 {
+		s.Warnings = append(s.Warnings, "shorter than 8 characters")
+		s.Suggestions = append(s.Suggestions, "use at least 8 characters, ideally more")
+	}
+
+	switch {
+	case s.EntropyBits >= 80:
+		s.Score = 4
+	case s.EntropyBits >= 60:
+		s.Score = 3
+	case s.EntropyBits >= 40:
+		s.Score = 2
+	case s.EntropyBits >= 25:
+		s.Score = 1
+	default:
+		s.Score = 0
+	}
+
+	// A common-password hit caps the score at 1 rather than flooring it
+	// there: a trivially weak common password (low entropy already scores
+	// 0) should still score 0, not get bumped up for being "merely" common.
+	if isCommon && s.Score > 1 {
+		s.Score = 1
+	}
+	return s
+}
+
+// deleetify maps common leetspeak substitutions back to letters so
+// dictionary and common-password checks can catch variants like "p4ssw0rd".
+func deleetify(s string) string {
+	replacer := strings.NewReplacer(
+		"0", "o",
+		"1", "l",
+		"3", "e",
+		"4", "a",
+		"5", "s",
+		"7", "t",
+		"@", "a",
+		"$", "s",
+		"!", "i",
+	)
+	return replacer.Replace(s)
+}
+
+// longestSequentialRun returns the length of the longest run of
+// consecutive ascending characters, e.g. "abcd" or "1234" -> 4.
+func longestSequentialRun(s string) int {
+	longest, current := 1, 1
+	for i := 1; i < len(s); i++ {
+		if s[i] == s[i-1]+1 {
+			current++
+		} else {
+			current = 1
 		}
+		if current > longest {
+			longest = current
+		}
+	}
+	if len(s) == 0 {
+		return 0
 	}
+	return longest
+}
 
-	// 3. Provide feedback based on the flags.
-	if hasMinLength && hasUppercase && hasNumber {
-		fmt.Println("  -> Password is valid.")
-		return // Exit the function early if everything is okay.
+// longestRepeatedRun returns the length of the longest run of the exact
+// same character repeated, e.g. "aaaa" -> 4.
+func longestRepeatedRun(s string) int {
+	longest, current := 0, 0
+	var prev rune
+	for i, r := range s {
+		if i > 0 && r == prev {
+			current++
+		} else {
+			current = 1
+		}
+		prev = r
+		if current > longest {
+			longest = current
+		}
 	}
+	return longest
+}
 
-	// If we're still here, the password is not valid. Let's give detailed feedback.
-	fmt.Println("  -> Password is NOT valid. Issues found:")
-	if !hasMinLength {
-		fmt.Println("    - Must be at least 8 characters long.")
+// looksLikeDate reports whether the password contains a plausible
+// 19xx/20xx year, a common way people weaken passwords with birthdays.
+func looksLikeDate(password string) bool {
+	for i := 0; i+4 <= len(password); i++ {
+		chunk := password[i : i+4]
+		allDigits := true
+		for _, c := range chunk {
+			if !unicode.IsDigit(c) {
+				allDigits = false
+				break
+			}
+		}
+		if allDigits && (strings.HasPrefix(chunk, "19") || strings.HasPrefix(chunk, "20")) {
+			return true
+		}
 	}
-	if !hasUppercase {
-		fmt.Println("    - Must contain at least one uppercase letter.")
+	return false
+}
+
+// printStrength prints a PasswordStrength report in the style of this
+// file's other challenge demos.
+func printStrength(password string, s PasswordStrength) {
+	fmt.Printf("Checking password: '%s'\n", password)
+	fmt.Printf("  -> Score: %d/4  (entropy: %.1f bits, ~%.0f guesses to crack)\n", s.Score, s.EntropyBits, s.EstimatedGuesses)
+	for _, w := range s.Warnings {
+		fmt.Println("    - Warning:", w)
 	}
-	if !hasNumber {
-		fmt.Println("    - Must contain at least one number.")
+	for _, sg := range s.Suggestions {
+		fmt.Println("    - Suggestion:", sg)
 	}
 }
 
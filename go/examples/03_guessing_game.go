@@ -2,33 +2,99 @@ package main
 
 import (
 	"bufio"
+	crand "crypto/rand"
+	"encoding/binary"
+	"flag"
 	"fmt"
+	"io"
 	"log"
+	"math"
 	"math/rand"
 	"os"
 	"strconv"
 	"strings"
-	"time"
 )
 
+// --- Secure RNG helpers ---
+// rand.Seed(time.Now().UnixNano()) is deprecated as of Go 1.20, and it's
+// also predictable: a player watching the wall clock can narrow down the
+// seed and recover the secret number. NewSecure/secureIntN below pull
+// randomness from the OS's CSPRNG instead. They're named and shaped as a
+// future `internal/rng` package would (NewDefault, NewSecure, and
+// secureIntN in place of its IntN) even
+// though they live in this single file for now - this repo has no go.mod
+// yet, so every go/examples/ file is a standalone `package main` run with
+// `go run <file>.go`, and can't import a sibling package.
+
+// NewDefault returns a seeded, non-cryptographic generator for reproducible
+// runs - used only when --deterministic is passed.
+func NewDefault(seed int64) *rand.Rand {
+	return rand.New(rand.NewSource(seed))
+}
+
+// NewSecure returns the OS's cryptographically secure randomness source.
+func NewSecure() io.Reader {
+	return crand.Reader
+}
+
+// secureIntN returns a uniform random int in [0, max) read from r, using
+// rejection sampling so the result isn't biased by max not evenly dividing
+// the range of a uint32.
+func secureIntN(r io.Reader, max int) int {
+	if max <= 0 {
+		log.Fatal("secureIntN: max must be positive")
+	}
+	bound := uint32(max)
+	// threshold is the largest multiple of bound that fits in a uint32;
+	// draws landing at or above it are discarded so every surviving
+	// outcome in [0, bound) remains equally likely.
+	threshold := (1<<32 - 1) - uint32(1<<32-1)%bound
+	for {
+		var buf [4]byte
+		if _, err := io.ReadFull(r, buf[:]); err != nil {
+			log.Fatal(err)
+		}
+		v := binary.BigEndian.Uint32(buf[:])
+		if v < threshold {
+			return int(v % bound)
+		}
+	}
+}
+
 // main is the entry point for our program.
+//
+// Running `go run 03_guessing_game.go solver` flips the game around: instead
+// of you guessing the computer's number, the computer guesses a number you're
+// thinking of, using the same binary-search idea --hints teaches below. See
+// runSolver.
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "solver" {
+		runSolver(os.Args[2:])
+		return
+	}
+
 	// --- Step 1: Generate a random secret number ---
 
-	// The default random number generator is deterministic, meaning it will
-	// produce the same sequence of numbers every time. To make it feel truly
-	// random, we need to give it a unique starting point (a "seed").
-	// Using the current time in nanoseconds is a great way to ensure the
-	// seed is different on each run.
-	rand.Seed(time.Now().UnixNano())
+	seed := flag.Int64("deterministic", 0, "use a seeded, repeatable RNG with this seed instead of the secure default (0 = off)")
+	hints := flag.Bool("hints", false, "after each wrong guess, print the shrinking range the number must be in and how many guesses optimal play still needs")
+	flag.Parse()
 
-	// rand.Intn(100) generates a random integer between 0 and 99.
-	// We add 1 to get a number in the range [1, 100].
-	secretNumber := rand.Intn(100) + 1
+	var secretNumber int
+	if *seed != 0 {
+		secretNumber = NewDefault(*seed).Intn(100) + 1
+	} else {
+		secretNumber = secureIntN(NewSecure(), 100) + 1
+	}
 
 	fmt.Println("I've chosen a random number between 1 and 100.")
 	fmt.Println("Can you guess it? You have 10 tries.")
 
+	// lo and hi track the narrowest range the secret number could still be
+	// in, given the LOW/HIGH feedback so far. --hints uses this to show the
+	// player the same information an optimal binary-search player would
+	// track in their head.
+	lo, hi := 1, 100
+
 	// --- Step 2: Set up a loop for 10 guesses ---
 
 	// We use a classic 'for' loop that will run exactly 10 times.
@@ -73,8 +139,14 @@ func main() {
 		// Here's the core game logic, using an if-else if-else chain.
 		if guess < secretNumber {
 			fmt.Println("Oops. Your guess was LOW.")
+			if guess+1 > lo {
+				lo = guess + 1
+			}
 		} else if guess > secretNumber {
 			fmt.Println("Oops. Your guess was HIGH.")
+			if guess-1 < hi {
+				hi = guess - 1
+			}
 		} else {
 			// The guess is correct!
 			fmt.Println("Good job! You guessed it!")
@@ -82,6 +154,24 @@ func main() {
 			// This is a clean way to stop since the game is won.
 			return
 		}
+
+		// --- Step 4.5: Show a hint, if --hints was passed ---
+
+		if *hints {
+			// ceil(log2(hi-lo+1)) is the fewest guesses a perfect binary
+			// search still needs to pin down one number in [lo, hi].
+			optimal := 0
+			if span := hi - lo + 1; span > 1 {
+				optimal = int(math.Ceil(math.Log2(float64(span))))
+			}
+			triesLeft := 10 - guesses
+			fmt.Printf("Hint: the number is between %d and %d.\n", lo, hi)
+			if optimal > triesLeft {
+				fmt.Printf("Even optimal play needs %d more guesses, but you only have %d left!\n", optimal, triesLeft)
+			} else {
+				fmt.Printf("Optimal play solves this in %d more guesses; you have %d left.\n", optimal, triesLeft)
+			}
+		}
 	}
 
 	// --- Step 5: Handle the case where the player runs out of guesses ---
@@ -90,3 +180,43 @@ func main() {
 	// this line of code will be executed, revealing the secret number.
 	fmt.Println("Sorry, you didn't guess my number. It was:", secretNumber)
 }
+
+// runSolver flips the game around: it plays the guesser's side, binary-
+// searching for a number you're thinking of by repeatedly proposing the
+// midpoint of the range still in play and asking whether that's higher,
+// lower, or correct. It keeps its own [lo, hi] range and calls you out if
+// your answers are ever inconsistent (no number could satisfy all of them).
+func runSolver(args []string) {
+	fs := flag.NewFlagSet("solver", flag.ExitOnError)
+	min := fs.Int("min", 1, "lower bound of the secret number, inclusive")
+	max := fs.Int("max", 100, "upper bound of the secret number, inclusive")
+	fs.Parse(args)
+
+	lo, hi := *min, *max
+	fmt.Printf("Think of a number between %d and %d. I'll find it.\n", lo, hi)
+
+	reader := bufio.NewReader(os.Stdin)
+	for lo <= hi {
+		guess := lo + (hi-lo)/2
+		fmt.Printf("Is it %d? (h = higher, l = lower, c = correct): ", guess)
+		input, err := reader.ReadString('\n')
+		if err != nil {
+			fmt.Println("Could not read input:", err)
+			os.Exit(1)
+		}
+
+		switch strings.TrimSpace(strings.ToLower(input)) {
+		case "h":
+			lo = guess + 1
+		case "l":
+			hi = guess - 1
+		case "c":
+			fmt.Printf("Got it! Your number was %d.\n", guess)
+			return
+		default:
+			fmt.Println("Please answer h, l, or c.")
+		}
+	}
+
+	fmt.Println("That's inconsistent: no number fits all of your answers. Were you cheating?")
+}
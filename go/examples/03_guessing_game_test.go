@@ -0,0 +1,138 @@
+// Tests for 03_guessing_game.go. Like every file in go/examples/, this repo
+// has no go.mod to tie files into one importable package, and 04_game_engine.go
+// duplicates the same secureIntN/NewDefault helpers under its own test file -
+// so these tests are named with a GuessingGame prefix to avoid colliding with
+// 04_game_engine_test.go's GameEngine-prefixed copies if both ever end up in
+// the same `go test` invocation. Run with:
+//
+//	go test 03_guessing_game.go 03_guessing_game_test.go
+
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+// TestGuessingGameSecureIntNDistribution checks that secureIntN's rejection
+// sampling doesn't introduce a modulo-bias skew across a range of bounds:
+// over many samples, every bucket in [0, max) should come up roughly as
+// often as every other one.
+func TestGuessingGameSecureIntNDistribution(t *testing.T) {
+	cases := []struct {
+		name    string
+		max     int
+		samples int
+	}{
+		{"small bound", 2, 100000},
+		{"ten-value range", 10, 100000},
+		{"non-power-of-two bound", 37, 100000},
+		{"full game range", 100, 200000},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			counts := make([]int, c.max)
+			src := NewSecure()
+			for i := 0; i < c.samples; i++ {
+				v := secureIntN(src, c.max)
+				if v < 0 || v >= c.max {
+					t.Fatalf("secureIntN(%d) returned out-of-range value %d", c.max, v)
+				}
+				counts[v]++
+			}
+
+			// Per-bucket counts are binomially distributed with stddev
+			// ~sqrt(expected), so a flat percentage tolerance gets
+			// statistically unsound (and flaky) once a case spreads the
+			// same sample budget over many buckets. A 5-stddev band keeps
+			// the false-positive rate low across every bucket in every case.
+			expected := float64(c.samples) / float64(c.max)
+			tolerance := 5 * math.Sqrt(expected)
+			for bucket, count := range counts {
+				diff := float64(count) - expected
+				if diff < -tolerance || diff > tolerance {
+					t.Errorf("bucket %d got %d samples, want close to %.0f (+/- %.0f)", bucket, count, expected, tolerance)
+				}
+			}
+		})
+	}
+}
+
+// TestGuessingGameNewDefaultIsReproducible checks that --deterministic's
+// seeded RNG produces the exact same sequence of guesses across independent
+// runs, for a range of seeds and draw counts.
+func TestGuessingGameNewDefaultIsReproducible(t *testing.T) {
+	cases := []struct {
+		name  string
+		seed  int64
+		max   int
+		draws int
+	}{
+		{"seed 42, default game range", 42, 100, 50},
+		{"seed 0, default game range", 0, 100, 50},
+		{"seed 7, small range", 7, 10, 200},
+		{"negative seed", -99, 1000, 50},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			first := make([]int, c.draws)
+			r1 := NewDefault(c.seed)
+			for i := range first {
+				first[i] = r1.Intn(c.max)
+			}
+
+			second := make([]int, c.draws)
+			r2 := NewDefault(c.seed)
+			for i := range second {
+				second[i] = r2.Intn(c.max)
+			}
+
+			for i := range first {
+				if first[i] != second[i] {
+					t.Fatalf("draw %d differs across runs with the same seed: %d != %d", i, first[i], second[i])
+				}
+			}
+		})
+	}
+}
+
+// TestGuessingGameNewDefaultDifferentSeedsDiverge is a sanity check that
+// --deterministic actually seeds the generator rather than silently
+// ignoring the seed, across several seed pairs.
+func TestGuessingGameNewDefaultDifferentSeedsDiverge(t *testing.T) {
+	cases := []struct {
+		name         string
+		seedA, seedB int64
+	}{
+		{"seeds 1 and 2", 1, 2},
+		{"seeds 0 and 1", 0, 1},
+		{"seeds 42 and -42", 42, -42},
+	}
+
+	const draws = 50
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			a := make([]int, draws)
+			r1 := NewDefault(c.seedA)
+			for i := range a {
+				a[i] = r1.Intn(1000)
+			}
+
+			b := make([]int, draws)
+			r2 := NewDefault(c.seedB)
+			for i := range b {
+				b[i] = r2.Intn(1000)
+			}
+
+			for i := range a {
+				if a[i] != b[i] {
+					return // found a difference, as expected
+				}
+			}
+			t.Fatal("two different seeds produced identical sequences; seed is probably not wired up")
+		})
+	}
+}
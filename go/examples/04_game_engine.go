@@ -0,0 +1,560 @@
+// Example 4: A Reusable Game Engine
+// Turns the hard-coded number-guessing loop from Example 3 into a small,
+// reusable engine so the same input/output loop can one day host other
+// mini-games (FizzBuzz-as-a-game, arithmetic drills, ...) without being
+// rewritten from scratch.
+//
+// NOTE ON PROJECT LAYOUT: every file under go/examples/ is a standalone,
+// runnable `package main` (run with `go run <file>.go`), and this repo has
+// no go.mod tying them together into importable packages yet. So the types
+// below are named and exported exactly as a future `gameengine` package
+// would name them (Game, Result, NumberGuess, ...) even though they live in
+// this single file for now - if/when this repo grows a module, this file's
+// contents are meant to move to gameengine/ almost unchanged.
+//
+// --hints turns the loop into a search-algorithm lesson: after every wrong
+// guess it prints the shrinking [lo, hi] interval and the information-
+// theoretic minimum number of guesses still needed (ceil(log2(hi-lo+1))),
+// warning the player when even that minimum is more than the tries they
+// have left. `go run 04_game_engine.go solver` flips the roles: the
+// program does the binary search and the human answers higher/lower/
+// correct, with inconsistent answers reported as an empty feasible range.
+
+package main
+
+import (
+	"bufio"
+	crand "crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"math/rand"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// --- Secure RNG helpers ---
+// See the matching section in 03_guessing_game.go: rand.Seed is deprecated
+// and predictable, so the secret number is drawn from the OS's CSPRNG by
+// default, with --deterministic available for reproducible runs/demos.
+// Duplicated here rather than imported because this repo has no go.mod
+// yet to share an internal/rng package across go/examples/ files.
+
+// NewDefault returns a seeded, non-cryptographic generator for reproducible
+// runs - used only when --deterministic is passed.
+func NewDefault(seed int64) *rand.Rand {
+	return rand.New(rand.NewSource(seed))
+}
+
+// NewSecure returns the OS's cryptographically secure randomness source.
+func NewSecure() io.Reader {
+	return crand.Reader
+}
+
+// secureIntN returns a uniform random int in [0, max) read from r, using
+// rejection sampling so the result isn't biased by max not evenly dividing
+// the range of a uint32.
+func secureIntN(r io.Reader, max int) int {
+	if max <= 0 {
+		log.Fatal("secureIntN: max must be positive")
+	}
+	bound := uint32(max)
+	threshold := (1<<32 - 1) - uint32(1<<32-1)%bound
+	for {
+		var buf [4]byte
+		if _, err := io.ReadFull(r, buf[:]); err != nil {
+			log.Fatal(err)
+		}
+		v := binary.BigEndian.Uint32(buf[:])
+		if v < threshold {
+			return int(v % bound)
+		}
+	}
+}
+
+// --- Core engine types ---
+
+// Result describes the outcome of a single round of a Game.
+type Result struct {
+	Feedback string // human-readable feedback, e.g. "LOW", "HIGH", "WIN"
+	Correct  bool
+}
+
+// Game is the interface every mini-game plugs into the shared CLI loop.
+type Game interface {
+	// Start prepares the game (picks a secret, resets counters, ...).
+	Start() error
+	// Round consumes one line of player input and returns the result.
+	Round(input string) (Result, error)
+	// Finished reports whether the game is over (won or out of tries).
+	Finished() bool
+}
+
+// Category identifies which family of mini-game is being played. Only
+// NumberGuess is implemented today; the others are reserved so --category
+// has somewhere to grow into.
+type Category string
+
+const (
+	CategoryNumberGuess Category = "number-guess"
+	CategoryFizzBuzz    Category = "fizzbuzz"
+	CategoryArithmetic  Category = "arithmetic"
+)
+
+// Difficulty is a named preset of game parameters plus a score multiplier.
+type Difficulty struct {
+	Name       string
+	Min, Max   int
+	MaxTries   int
+	Multiplier int
+}
+
+// difficulties maps each supported preset's name to its parameters.
+var difficulties = map[string]Difficulty{
+	"baby":    {Name: "baby", Min: 1, Max: 10, MaxTries: 5, Multiplier: 1},
+	"easy":    {Name: "easy", Min: 1, Max: 50, MaxTries: 8, Multiplier: 2},
+	"normal":  {Name: "normal", Min: 1, Max: 100, MaxTries: 10, Multiplier: 3},
+	"hard":    {Name: "hard", Min: 1, Max: 500, MaxTries: 10, Multiplier: 4},
+	"extreme": {Name: "extreme", Min: 1, Max: 1000, MaxTries: 8, Multiplier: 5},
+}
+
+// --- NumberGuess: the classic guessing game as a Game implementation ---
+
+// NumberGuess implements Game for the "guess the secret number" mini-game.
+type NumberGuess struct {
+	difficulty Difficulty
+	secret     int
+	tries      int
+	won        bool
+	intn       func(max int) int // source of randomness for picking the secret
+
+	hintsEnabled bool
+	lo, hi       int // feasible range, narrowed after every guess; only shown when hints are on
+}
+
+// NewNumberGuess builds a NumberGuess for the given difficulty preset. intn
+// should return a uniform random int in [0, max); pass secureIntN bound to
+// NewSecure() by default, or NewDefault(seed).Intn for --deterministic runs.
+func NewNumberGuess(d Difficulty, intn func(max int) int) *NumberGuess {
+	return &NumberGuess{difficulty: d, intn: intn}
+}
+
+func (g *NumberGuess) Start() error {
+	g.secret = g.intn(g.difficulty.Max-g.difficulty.Min+1) + g.difficulty.Min
+	g.tries = 0
+	g.won = false
+	g.lo, g.hi = g.difficulty.Min, g.difficulty.Max
+	return nil
+}
+
+func (g *NumberGuess) Round(input string) (Result, error) {
+	guess, err := strconv.Atoi(strings.TrimSpace(input))
+	if err != nil {
+		return Result{}, fmt.Errorf("invalid guess %q: not a number", input)
+	}
+	g.tries++
+
+	switch {
+	case guess < g.secret:
+		if guess+1 > g.lo {
+			g.lo = guess + 1
+		}
+		return Result{Feedback: "LOW"}, nil
+	case guess > g.secret:
+		if guess-1 < g.hi {
+			g.hi = guess - 1
+		}
+		return Result{Feedback: "HIGH"}, nil
+	default:
+		g.won = true
+		return Result{Feedback: "WIN", Correct: true}, nil
+	}
+}
+
+// optimalGuessesRemaining is the information-theoretic minimum number of
+// further guesses needed to pin down a value in [g.lo, g.hi] via binary
+// search: ceil(log2(hi-lo+1)).
+func (g *NumberGuess) optimalGuessesRemaining() int {
+	span := float64(g.hi - g.lo + 1)
+	if span <= 1 {
+		return 0
+	}
+	return int(math.Ceil(math.Log2(span)))
+}
+
+// printHint reports the shrinking interval and how many guesses optimal
+// binary search still needs, warning the player if that is more than the
+// tries they have left.
+func (g *NumberGuess) printHint() {
+	optimal := g.optimalGuessesRemaining()
+	triesLeft := g.difficulty.MaxTries - g.tries
+	fmt.Printf("Hint: the number is between %d and %d.\n", g.lo, g.hi)
+	if optimal > triesLeft {
+		fmt.Printf("Even optimal play needs %d more guesses, but you only have %d left!\n", optimal, triesLeft)
+	} else {
+		fmt.Printf("Optimal play solves this in %d more guesses; you have %d left.\n", optimal, triesLeft)
+	}
+}
+
+func (g *NumberGuess) Finished() bool {
+	return g.won || g.tries >= g.difficulty.MaxTries
+}
+
+// score awards points for a win: the multiplier scaled by unused tries,
+// with at least 1 point for a last-guess win.
+func (g *NumberGuess) score() int {
+	if !g.won {
+		return 0
+	}
+	remaining := g.difficulty.MaxTries - g.tries + 1
+	return remaining * g.difficulty.Multiplier
+}
+
+// --- Score persistence ---
+
+// ScoreRecord is one completed game, appended to the leaderboard file.
+type ScoreRecord struct {
+	Player     string    `json:"player"`
+	Category   string    `json:"category"`
+	Difficulty string    `json:"difficulty"`
+	Score      int       `json:"score"`
+	Duration   float64   `json:"duration_seconds"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// scoresFilePath returns $XDG_DATA_HOME/learn-go-games/scores.json, falling
+// back to $HOME/.local/share when XDG_DATA_HOME isn't set.
+func scoresFilePath() (string, error) {
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dataHome = filepath.Join(home, ".local", "share")
+	}
+	dir := filepath.Join(dataHome, "learn-go-games")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "scores.json"), nil
+}
+
+// appendScore records one game as a line of JSON in the scores file.
+func appendScore(path string, rec ScoreRecord) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
+
+// loadScores reads every ScoreRecord previously appended to path.
+func loadScores(path string) ([]ScoreRecord, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []ScoreRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var rec ScoreRecord
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			return nil, err
+		}
+		records = append(records, rec)
+	}
+	return records, scanner.Err()
+}
+
+// printLeaderboard prints the top N scores per category/difficulty pair.
+func printLeaderboard(records []ScoreRecord, topN int) {
+	grouped := make(map[string][]ScoreRecord)
+	for _, rec := range records {
+		key := rec.Category + "/" + rec.Difficulty
+		grouped[key] = append(grouped[key], rec)
+	}
+
+	keys := make([]string, 0, len(grouped))
+	for key := range grouped {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		group := grouped[key]
+		sort.Slice(group, func(i, j int) bool { return group[i].Score > group[j].Score })
+		fmt.Printf("=== %s ===\n", key)
+		for i, rec := range group {
+			if i >= topN {
+				break
+			}
+			fmt.Printf("%2d. %-12s %4d pts  (%.1fs, %s)\n",
+				i+1, rec.Player, rec.Score, rec.Duration, rec.Timestamp.Format(time.RFC3339))
+		}
+	}
+}
+
+// --- CLI wiring ---
+
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "solver" {
+		runSolver(os.Args[2:])
+		return
+	}
+
+	category := flag.String("category", string(CategoryNumberGuess), "mini-game category to play")
+	difficultyName := flag.String("difficulty", "normal", "difficulty preset: baby, easy, normal, hard, extreme")
+	player := flag.String("player", "player1", "name recorded alongside the score")
+	leaderboard := flag.Bool("leaderboard", false, "print the top scores and exit")
+	topN := flag.Int("top", 5, "how many scores to show per category/difficulty with --leaderboard")
+	hints := flag.Bool("hints", false, "show the shrinking [lo, hi] range and optimal-guesses-remaining after each wrong guess")
+	seed := flag.Int64("deterministic", 0, "use a seeded, repeatable RNG with this seed instead of the secure default (0 = off)")
+	server := flag.String("server", "", "host:port of a guess-server (see 05_guess_server.go) to play against instead of a local game")
+	room := flag.String("room", "lobby", "room to join when --server is set")
+	roomMode := flag.String("room-mode", "", "race or turns; picks the room's mode if you're the one creating it when --server is set")
+	flag.Parse()
+
+	if *server != "" {
+		playOverNetwork(*server, *room, *player, *roomMode)
+		return
+	}
+
+	path, err := scoresFilePath()
+	if err != nil {
+		fmt.Println("Could not locate the scores file:", err)
+		os.Exit(1)
+	}
+
+	if *leaderboard {
+		records, err := loadScores(path)
+		if err != nil {
+			fmt.Println("Could not read the scores file:", err)
+			os.Exit(1)
+		}
+		if len(records) == 0 {
+			fmt.Println("No scores recorded yet.")
+			return
+		}
+		printLeaderboard(records, *topN)
+		return
+	}
+
+	if Category(*category) != CategoryNumberGuess {
+		fmt.Printf("Category %q isn't implemented yet; only %q currently plays.\n", *category, CategoryNumberGuess)
+		return
+	}
+
+	difficulty, ok := difficulties[*difficultyName]
+	if !ok {
+		fmt.Printf("Unknown difficulty %q. Choose one of: baby, easy, normal, hard, extreme.\n", *difficultyName)
+		return
+	}
+
+	var intn func(max int) int
+	if *seed != 0 {
+		intn = NewDefault(*seed).Intn
+	} else {
+		secure := NewSecure()
+		intn = func(max int) int { return secureIntN(secure, max) }
+	}
+	game := NewNumberGuess(difficulty, intn)
+	game.hintsEnabled = *hints
+	if err := game.Start(); err != nil {
+		fmt.Println("Could not start the game:", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("I've chosen a number between %d and %d. You have %d tries.\n",
+		difficulty.Min, difficulty.Max, difficulty.MaxTries)
+
+	started := time.Now()
+	reader := bufio.NewReader(os.Stdin)
+	for !game.Finished() {
+		fmt.Printf("Guess #%d: ", game.tries+1)
+		input, err := reader.ReadString('\n')
+		if err != nil {
+			fmt.Println("Could not read input:", err)
+			os.Exit(1)
+		}
+
+		result, err := game.Round(input)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		switch result.Feedback {
+		case "LOW":
+			fmt.Println("Oops. Your guess was LOW.")
+		case "HIGH":
+			fmt.Println("Oops. Your guess was HIGH.")
+		case "WIN":
+			fmt.Println("Good job! You guessed it!")
+		}
+		if game.hintsEnabled && !result.Correct {
+			game.printHint()
+		}
+	}
+
+	score := game.score()
+	if !game.won {
+		fmt.Println("Sorry, you didn't guess my number. It was:", game.secret)
+	}
+
+	rec := ScoreRecord{
+		Player:     *player,
+		Category:   *category,
+		Difficulty: difficulty.Name,
+		Score:      score,
+		Duration:   time.Since(started).Seconds(),
+		Timestamp:  time.Now(),
+	}
+	if err := appendScore(path, rec); err != nil {
+		fmt.Println("Could not save your score:", err)
+	}
+}
+
+// runSolver plays the guesser's side of the game: it binary-searches for a
+// secret number the human has picked and is keeping in their head, asking
+// "higher/lower/correct" after each proposed guess. It tracks the feasible
+// [lo, hi] interval itself and reports the human's answers as inconsistent
+// (i.e. cheating or a mistake) if that interval ever becomes empty.
+func runSolver(args []string) {
+	fs := flag.NewFlagSet("solver", flag.ExitOnError)
+	min := fs.Int("min", 1, "lower bound of the secret number, inclusive")
+	max := fs.Int("max", 100, "upper bound of the secret number, inclusive")
+	fs.Parse(args)
+
+	lo, hi := *min, *max
+	fmt.Printf("Think of a number between %d and %d. I'll find it.\n", lo, hi)
+
+	reader := bufio.NewReader(os.Stdin)
+	for lo <= hi {
+		guess := lo + (hi-lo)/2
+		fmt.Printf("Is it %d? (h = higher, l = lower, c = correct): ", guess)
+		input, err := reader.ReadString('\n')
+		if err != nil {
+			fmt.Println("Could not read input:", err)
+			os.Exit(1)
+		}
+
+		switch strings.TrimSpace(strings.ToLower(input)) {
+		case "h":
+			lo = guess + 1
+		case "l":
+			hi = guess - 1
+		case "c":
+			fmt.Printf("Got it! Your number was %d.\n", guess)
+			return
+		default:
+			fmt.Println("Please answer h, l, or c.")
+		}
+	}
+
+	fmt.Println("That's inconsistent: no number fits all of your answers. Were you cheating?")
+}
+
+// playOverNetwork joins a guess-server room and plays using the same line
+// protocol as 06_guess_client.go (JOIN/GUESS out, LOW/HIGH/WIN/LOST/PEER in),
+// letting this single-player CLI double as a multiplayer client.
+func playOverNetwork(addr, room, name, mode string) {
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		fmt.Println("Could not connect to", addr, ":", err)
+		os.Exit(1)
+	}
+	defer conn.Close()
+
+	if mode != "" {
+		fmt.Fprintf(conn, "JOIN %s %s %s\n", room, name, mode)
+	} else {
+		fmt.Fprintf(conn, "JOIN %s %s\n", room, name)
+	}
+	fmt.Printf("Joined room %q as %q. Type a number and press Enter to guess.\n", room, name)
+
+	// done closes when the connection ends; terminal fires as soon as our
+	// own game result (WIN/LOST) has printed. Waiting on whichever comes
+	// first (rather than only on done) means a piped/non-interactive run
+	// doesn't hang forever - the room doesn't close the socket just
+	// because one player's game finished.
+	done := make(chan struct{})
+	terminal := make(chan struct{}, 1)
+	go func() {
+		defer close(done)
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			fields := strings.Fields(scanner.Text())
+			if len(fields) == 0 {
+				continue
+			}
+			switch fields[0] {
+			case "LOW":
+				fmt.Println("Oops. Your guess was LOW.")
+			case "HIGH":
+				fmt.Println("Oops. Your guess was HIGH.")
+			case "WIN":
+				fmt.Println("Good job! You guessed it!")
+				signalTerminal(terminal)
+			case "LOST":
+				fmt.Println("Game over. The secret number was:", fields[1])
+				signalTerminal(terminal)
+			case "WAIT":
+				fmt.Println("It's not your turn yet - wait for the other players.")
+			case "PEER":
+				if len(fields) == 4 {
+					fmt.Printf("%s guessed %s: %s\n", fields[1], fields[2], fields[3])
+				}
+			default:
+				fmt.Println(scanner.Text())
+			}
+		}
+		fmt.Println("Disconnected from server.")
+	}()
+
+	stdin := bufio.NewScanner(os.Stdin)
+	for stdin.Scan() {
+		guess := strings.TrimSpace(stdin.Text())
+		if guess == "" {
+			continue
+		}
+		fmt.Fprintf(conn, "GUESS %s\n", guess)
+	}
+	select {
+	case <-terminal:
+	case <-done:
+	}
+}
+
+// signalTerminal sends on terminal without blocking if it's already full
+// (a second WIN/LOST line should never arrive, but this stays safe either way).
+func signalTerminal(terminal chan<- struct{}) {
+	select {
+	case terminal <- struct{}{}:
+	default:
+	}
+}
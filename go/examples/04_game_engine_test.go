@@ -0,0 +1,94 @@
+// Tests for 04_game_engine.go. That file duplicates secureIntN/NewDefault
+// from 03_guessing_game.go (no go.mod to share them via), so these tests carry
+// a GameEngine prefix to avoid colliding with 03_guessing_game_test.go's
+// GuessingGame-prefixed copies if both ever end up in the same `go test`
+// invocation. Run with:
+//
+//	go test 04_game_engine.go 04_game_engine_test.go
+
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+// TestGameEngineSecureIntNDistribution mirrors the check in
+// 03_guessing_game_test.go: 04_game_engine.go duplicates secureIntN, so it
+// needs its own copy of the test to guard against the duplicate drifting.
+func TestGameEngineSecureIntNDistribution(t *testing.T) {
+	cases := []struct {
+		name    string
+		max     int
+		samples int
+	}{
+		{"small bound", 2, 100000},
+		{"baby difficulty bound", 10, 100000},
+		{"non-power-of-two bound", 37, 100000},
+		{"extreme difficulty bound", 1000, 2000000},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			counts := make([]int, c.max)
+			src := NewSecure()
+			for i := 0; i < c.samples; i++ {
+				v := secureIntN(src, c.max)
+				if v < 0 || v >= c.max {
+					t.Fatalf("secureIntN(%d) returned out-of-range value %d", c.max, v)
+				}
+				counts[v]++
+			}
+
+			// Per-bucket counts are binomially distributed with stddev
+			// ~sqrt(expected), so a flat percentage tolerance gets
+			// statistically unsound (and flaky) once a case spreads the
+			// same sample budget over many buckets. A 5-stddev band keeps
+			// the false-positive rate low across every bucket in every case.
+			expected := float64(c.samples) / float64(c.max)
+			tolerance := 5 * math.Sqrt(expected)
+			for bucket, count := range counts {
+				diff := float64(count) - expected
+				if diff < -tolerance || diff > tolerance {
+					t.Errorf("bucket %d got %d samples, want close to %.0f (+/- %.0f)", bucket, count, expected, tolerance)
+				}
+			}
+		})
+	}
+}
+
+// TestGameEngineNumberGuessDeterministicIsReproducible checks that
+// --deterministic picks the exact same secret (and so plays out
+// identically) across runs, for a range of seeds and difficulty presets.
+func TestGameEngineNumberGuessDeterministicIsReproducible(t *testing.T) {
+	cases := []struct {
+		name       string
+		seed       int64
+		difficulty string
+	}{
+		{"seed 7, normal", 7, "normal"},
+		{"seed 0, baby", 0, "baby"},
+		{"seed 42, hard", 42, "hard"},
+		{"negative seed, extreme", -13, "extreme"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			difficulty := difficulties[c.difficulty]
+
+			g1 := NewNumberGuess(difficulty, NewDefault(c.seed).Intn)
+			if err := g1.Start(); err != nil {
+				t.Fatal(err)
+			}
+
+			g2 := NewNumberGuess(difficulty, NewDefault(c.seed).Intn)
+			if err := g2.Start(); err != nil {
+				t.Fatal(err)
+			}
+
+			if g1.secret != g2.secret {
+				t.Fatalf("same seed produced different secrets: %d != %d", g1.secret, g2.secret)
+			}
+		})
+	}
+}
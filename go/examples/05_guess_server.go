@@ -0,0 +1,413 @@
+// Example 5a: Networked Multiplayer Guessing Game - Server
+// Hosts guess-the-number "rooms" over a plain-text TCP line protocol so
+// several players can compete (or co-operate) against the same secret
+// number, plus an HTTP /stats endpoint for leaderboards.
+//
+// Line protocol (one line per message, each ending in '\n'):
+//
+//	client -> server: JOIN <room> <name> [mode]   (must be the first line sent)
+//	client -> server: GUESS <n>
+//	server -> client: LOW | HIGH
+//	server -> client: WIN <turns> <seconds>
+//	server -> client: LOST <secret>
+//	server -> client: WAIT                 (turns mode: not your turn yet)
+//	server -> clients: PEER <name> <guess> <verdict>   (broadcast to everyone else in the room)
+//
+// Rooms support two modes, picked when the room is first created: whoever's
+// JOIN line creates the room (the first one to name it) may pick the mode
+// with an optional 4th field; later joiners' mode field, if any, is ignored
+// since the room already exists. Omitting it falls back to the server's
+// --mode flag.
+//   - race:  anyone can guess at any time; the first correct guess wins and
+//     every other client in the room receives LOST.
+//   - turns: clients take turns guessing against a shared guess budget, as
+//     in a co-op game; guessing out of turn gets WAIT instead.
+//
+// NOTE ON PROJECT LAYOUT: like every other file in go/examples/, this is a
+// standalone `package main` run with `go run 05_guess_server.go`. In a
+// multi-package project this would live at cmd/guess-server/main.go.
+
+package main
+
+import (
+	"bufio"
+	crand "crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// lineReader is a tiny wrapper around bufio.Reader that turns read errors
+// (including a clean disconnect) into a simple "ok" bool.
+type lineReader struct {
+	r *bufio.Reader
+}
+
+func newLineReader(r io.Reader) *lineReader {
+	return &lineReader{r: bufio.NewReader(r)}
+}
+
+func (l *lineReader) readLine() (string, bool) {
+	line, err := l.r.ReadString('\n')
+	if err != nil && line == "" {
+		return "", false
+	}
+	return strings.TrimSpace(line), true
+}
+
+// --- Shared RNG helper (see 03_guessing_game.go for the full writeup) ---
+
+func secureIntN(r io.Reader, max int) int {
+	if max <= 0 {
+		log.Fatal("secureIntN: max must be positive")
+	}
+	bound := uint32(max)
+	threshold := (1<<32 - 1) - uint32(1<<32-1)%bound
+	for {
+		var buf [4]byte
+		if _, err := io.ReadFull(r, buf[:]); err != nil {
+			log.Fatal(err)
+		}
+		v := binary.BigEndian.Uint32(buf[:])
+		if v < threshold {
+			return int(v % bound)
+		}
+	}
+}
+
+// --- Room ---
+
+// guessMsg is one GUESS command handed off from a client's reader goroutine
+// to its room's single goroutine, which is the only place room state
+// (secret, turn order, budget) is mutated.
+type guessMsg struct {
+	client *client
+	value  int
+}
+
+// client pairs a connection with an outbox of pending writes to it. The
+// room's goroutine and the connection's own handleConn goroutine (for ERROR
+// replies, before it sends on leaves) push onto outbox; a dedicated
+// writeLoop goroutine drains it onto the socket, so a slow or unresponsive
+// client blocks on its own write, never the room's guess/join processing.
+type client struct {
+	conn   net.Conn
+	name   string
+	outbox chan string
+}
+
+func newClient(conn net.Conn, name string) *client {
+	c := &client{conn: conn, name: name, outbox: make(chan string, 32)}
+	go c.writeLoop()
+	return c
+}
+
+func (c *client) writeLoop() {
+	for msg := range c.outbox {
+		c.conn.SetWriteDeadline(time.Now().Add(5 * time.Second))
+		if _, err := io.WriteString(c.conn, msg); err != nil {
+			return
+		}
+	}
+}
+
+// send queues a message for this client without blocking the caller (the
+// room's single goroutine). If the client is too far behind to keep up,
+// the message is dropped rather than stalling every other player.
+func (c *client) send(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	select {
+	case c.outbox <- msg:
+	default:
+		log.Printf("guess-server: dropping message to %q, outbox full", c.name)
+	}
+}
+
+// room owns one secret number and the clients currently playing for it. All
+// state is only ever touched from room.run, so no locking is needed there.
+type room struct {
+	id       string
+	mode     string // "race" or "turns"
+	min, max int
+	budget   int // remaining shared guesses in turns mode; unused in race mode
+	secret   int
+	started  time.Time
+	clients  []*client
+	turnIdx  int
+	done     bool
+
+	joins   chan *client
+	leaves  chan *client
+	guesses chan guessMsg
+
+	stats *statsStore
+}
+
+func newRoom(id, mode string, min, max, budget int, rng io.Reader, stats *statsStore) *room {
+	return &room{
+		id:      id,
+		mode:    mode,
+		min:     min,
+		max:     max,
+		budget:  budget,
+		secret:  secureIntN(rng, max-min+1) + min,
+		started: time.Now(),
+		joins:   make(chan *client),
+		leaves:  make(chan *client),
+		guesses: make(chan guessMsg),
+		stats:   stats,
+	}
+}
+
+// run is the room's single goroutine; every state change funnels through it.
+func (r *room) run() {
+	for {
+		select {
+		case c := <-r.joins:
+			r.clients = append(r.clients, c)
+		case c := <-r.leaves:
+			r.removeClient(c)
+		case g := <-r.guesses:
+			r.handleGuess(g)
+		}
+	}
+}
+
+func (r *room) removeClient(c *client) {
+	for i, other := range r.clients {
+		if other == c {
+			r.clients = append(r.clients[:i], r.clients[i+1:]...)
+			// handleConn stops sending before it sends on leaves, so once
+			// the client is removed here only this goroutine could still
+			// send on outbox, which makes this the safe place to close it.
+			close(c.outbox)
+			return
+		}
+	}
+}
+
+func (r *room) broadcastExcept(skip *client, format string, args ...interface{}) {
+	for _, c := range r.clients {
+		if c != skip {
+			c.send(format, args...)
+		}
+	}
+}
+
+func (r *room) handleGuess(g guessMsg) {
+	if r.done {
+		g.client.send("LOST %d\n", r.secret)
+		return
+	}
+
+	if r.mode == "turns" {
+		if len(r.clients) == 0 || r.clients[r.turnIdx%len(r.clients)] != g.client {
+			g.client.send("WAIT\n")
+			return
+		}
+		r.budget--
+		r.turnIdx++
+	}
+
+	var verdict string
+	switch {
+	case g.value < r.secret:
+		verdict = "LOW"
+		g.client.send("LOW\n")
+	case g.value > r.secret:
+		verdict = "HIGH"
+		g.client.send("HIGH\n")
+	default:
+		verdict = "WIN"
+		turns := r.turnIdx
+		if r.mode != "turns" {
+			turns = 1 // race mode doesn't track individual turn counts
+		}
+		g.client.send("WIN %d %.1f\n", turns, time.Since(r.started).Seconds())
+		r.stats.record(statRecord{
+			Room:     r.id,
+			Mode:     r.mode,
+			Player:   g.client.name,
+			Turns:    turns,
+			Seconds:  time.Since(r.started).Seconds(),
+			Finished: time.Now(),
+		})
+		r.done = true
+		r.broadcastExcept(g.client, "LOST %d\n", r.secret)
+	}
+
+	r.broadcastExcept(g.client, "PEER %s %d %s\n", g.client.name, g.value, verdict)
+
+	if r.mode == "turns" && !r.done && r.budget <= 0 {
+		r.done = true
+		for _, c := range r.clients {
+			c.send("LOST %d\n", r.secret)
+		}
+	}
+}
+
+// --- Stats ---
+
+type statRecord struct {
+	Room     string    `json:"room"`
+	Mode     string    `json:"mode"`
+	Player   string    `json:"player"`
+	Turns    int       `json:"turns"`
+	Seconds  float64   `json:"seconds"`
+	Finished time.Time `json:"finished"`
+}
+
+type statsStore struct {
+	mu      sync.Mutex
+	records []statRecord
+}
+
+func (s *statsStore) record(rec statRecord) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, rec)
+}
+
+func (s *statsStore) snapshot() []statRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]statRecord, len(s.records))
+	copy(out, s.records)
+	return out
+}
+
+// --- Server ---
+
+type server struct {
+	mu          sync.Mutex
+	rooms       map[string]*room
+	defaultMode string
+	min, max    int
+	budget      int
+	rng         io.Reader
+	stats       *statsStore
+}
+
+// getOrCreateRoom returns the named room, spinning up a new one (and its
+// goroutine) on first use. mode, if non-empty, picks the new room's mode;
+// it's ignored if the room already exists, since a room's mode is fixed at
+// creation.
+func (s *server) getOrCreateRoom(id, mode string) *room {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r, ok := s.rooms[id]; ok {
+		return r
+	}
+	if mode == "" {
+		mode = s.defaultMode
+	}
+	r := newRoom(id, mode, s.min, s.max, s.budget, s.rng, s.stats)
+	s.rooms[id] = r
+	go r.run()
+	return r
+}
+
+func (s *server) handleConn(conn net.Conn) {
+	defer conn.Close()
+
+	scanner := newLineReader(conn)
+	first, ok := scanner.readLine()
+	if !ok {
+		return
+	}
+	fields := strings.Fields(first)
+	if len(fields) < 3 || len(fields) > 4 || !strings.EqualFold(fields[0], "JOIN") {
+		fmt.Fprintf(conn, "ERROR first line must be: JOIN <room> <name> [mode]\n")
+		return
+	}
+
+	mode := ""
+	if len(fields) == 4 {
+		mode = strings.ToLower(fields[3])
+		if mode != "race" && mode != "turns" {
+			fmt.Fprintf(conn, "ERROR unknown mode %q: must be race or turns\n", fields[3])
+			return
+		}
+	}
+
+	r := s.getOrCreateRoom(fields[1], mode)
+	c := newClient(conn, fields[2])
+	r.joins <- c
+	defer func() { r.leaves <- c }()
+
+	for {
+		line, ok := scanner.readLine()
+		if !ok {
+			return
+		}
+		parts := strings.Fields(line)
+		if len(parts) != 2 || !strings.EqualFold(parts[0], "GUESS") {
+			c.send("ERROR expected: GUESS <n>\n")
+			continue
+		}
+		value, err := strconv.Atoi(parts[1])
+		if err != nil {
+			c.send("ERROR invalid guess %q\n", parts[1])
+			continue
+		}
+		r.guesses <- guessMsg{client: c, value: value}
+	}
+}
+
+func (s *server) statsHandler(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.stats.snapshot())
+}
+
+func main() {
+	tcpAddr := flag.String("addr", ":4000", "TCP address to listen on for guess clients")
+	httpAddr := flag.String("http-addr", ":4001", "HTTP address to serve /stats on")
+	mode := flag.String("mode", "race", "default room mode for newly created rooms: race or turns")
+	min := flag.Int("min", 1, "lower bound of the secret number, inclusive")
+	max := flag.Int("max", 100, "upper bound of the secret number, inclusive")
+	budget := flag.Int("budget", 10, "shared guess budget per room in turns mode")
+	flag.Parse()
+
+	if *mode != "race" && *mode != "turns" {
+		log.Fatalf("unknown --mode %q: must be race or turns", *mode)
+	}
+
+	srv := &server{
+		rooms:       make(map[string]*room),
+		defaultMode: *mode,
+		min:         *min,
+		max:         *max,
+		budget:      *budget,
+		rng:         crand.Reader,
+		stats:       &statsStore{},
+	}
+
+	http.HandleFunc("/stats", srv.statsHandler)
+	go func() {
+		log.Fatal(http.ListenAndServe(*httpAddr, nil))
+	}()
+
+	listener, err := net.Listen("tcp", *tcpAddr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("guess-server listening on %s (stats on http://%s/stats)\n", *tcpAddr, *httpAddr)
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			log.Println("accept error:", err)
+			continue
+		}
+		go srv.handleConn(conn)
+	}
+}
@@ -0,0 +1,122 @@
+// Example 5b: Networked Multiplayer Guessing Game - Client
+// Connects to the server from 05_guess_server.go, joins a room, and plays
+// the same guessing game over the wire. Run a few of these against one
+// server to see the "race" and "turns" room modes, and the PEER broadcasts
+// that let players see each other's guesses.
+//
+// Usage:
+//
+//	go run 06_guess_client.go --server localhost:4000 --room lobby --name alice
+//
+// NOTE ON PROJECT LAYOUT: standalone `package main`, like every file in
+// go/examples/ - would live at cmd/guess-client/main.go in a multi-package
+// project.
+
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+)
+
+func main() {
+	addr := flag.String("server", "localhost:4000", "guess-server address to connect to")
+	room := flag.String("room", "lobby", "room to join; created on the server if it doesn't exist yet")
+	name := flag.String("name", "player1", "name shown to other players in this room")
+	mode := flag.String("mode", "", "race or turns; picks the room's mode if you're the one creating it (ignored otherwise, default server --mode)")
+	flag.Parse()
+
+	conn, err := net.Dial("tcp", *addr)
+	if err != nil {
+		fmt.Println("Could not connect to", *addr, ":", err)
+		os.Exit(1)
+	}
+	defer conn.Close()
+
+	if *mode != "" {
+		fmt.Fprintf(conn, "JOIN %s %s %s\n", *room, *name, *mode)
+	} else {
+		fmt.Fprintf(conn, "JOIN %s %s\n", *room, *name)
+	}
+	fmt.Printf("Joined room %q as %q. Type a number and press Enter to guess.\n", *room, *name)
+
+	// Server messages (our own guess result, and PEER broadcasts from other
+	// players) can arrive at any time, so a dedicated goroutine prints
+	// everything the server sends while main reads guesses from stdin. done
+	// closes when the connection ends; terminal fires as soon as our own
+	// game result (WIN/LOST) is printed, whichever the room's mode reaches.
+	done := make(chan struct{})
+	terminal := make(chan struct{}, 1)
+	go func() {
+		defer close(done)
+		printServerMessages(conn, terminal)
+	}()
+
+	stdin := bufio.NewScanner(os.Stdin)
+	for stdin.Scan() {
+		guess := strings.TrimSpace(stdin.Text())
+		if guess == "" {
+			continue
+		}
+		fmt.Fprintf(conn, "GUESS %s\n", guess)
+	}
+
+	// stdin ran out (interactive Ctrl-D, or a piped/non-interactive run);
+	// don't tear the connection down until the reader goroutine has had a
+	// chance to print our game's outcome or the connection itself closes.
+	select {
+	case <-terminal:
+	case <-done:
+	}
+}
+
+// printServerMessages prints every line the server sends, translating the
+// line protocol into the same friendly phrasing as the single-player game.
+// It signals terminal once a WIN or LOST for this connection has printed.
+func printServerMessages(conn net.Conn, terminal chan<- struct{}) {
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		line := scanner.Text()
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "LOW":
+			fmt.Println("Oops. Your guess was LOW.")
+		case "HIGH":
+			fmt.Println("Oops. Your guess was HIGH.")
+		case "WIN":
+			fmt.Println("Good job! You guessed it!", line)
+			signalTerminal(terminal)
+		case "LOST":
+			fmt.Println("Game over. The secret number was:", fields[1])
+			signalTerminal(terminal)
+		case "WAIT":
+			fmt.Println("It's not your turn yet - wait for the other players.")
+		case "PEER":
+			if len(fields) == 4 {
+				fmt.Printf("%s guessed %s: %s\n", fields[1], fields[2], fields[3])
+			}
+		case "ERROR":
+			fmt.Println("Server error:", strings.TrimPrefix(line, "ERROR "))
+		default:
+			fmt.Println(line)
+		}
+	}
+	fmt.Println("Disconnected from server.")
+}
+
+// signalTerminal sends on terminal without blocking if it's already full
+// (a second WIN/LOST line should never arrive, but this stays safe either way).
+func signalTerminal(terminal chan<- struct{}) {
+	select {
+	case terminal <- struct{}{}:
+	default:
+	}
+}